@@ -0,0 +1,49 @@
+package signalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AuthError is returned when an AccessTokenProvider set via WithAccessTokenProvider fails,
+// distinguishing authentication failures from other negotiate/dial/transport errors.
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("signalr: access token provider failed: %v", e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// resolveHeaders builds the request headers for httpConn, consulting its AccessTokenProvider
+// (if any) on every call so a refreshed token is used for each HTTP request, WebSocket dial and
+// reconnect attempt. The resulting "Authorization" header takes precedence over anything
+// returned by httpConn.headers.
+//
+// resolveHeaders is called concurrently once a Connection is in use (e.g. from a Long Polling
+// connection's Read and Write, or from a reconnect racing an in-flight request), so it always
+// clones the http.Header returned by httpConn.headers before mutating it — callers are free to
+// return the same cached instance from that function on every call.
+func resolveHeaders(ctx context.Context, httpConn *httpConnection) (http.Header, error) {
+	var headers http.Header
+	if httpConn.headers != nil {
+		headers = httpConn.headers().Clone()
+	} else {
+		headers = http.Header{}
+	}
+
+	if httpConn.accessTokenProvider != nil {
+		token, err := httpConn.accessTokenProvider(ctx)
+		if err != nil {
+			return nil, &AuthError{Err: err}
+		}
+		headers.Set("Authorization", "Bearer "+token)
+	}
+
+	return headers, nil
+}
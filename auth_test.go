@@ -0,0 +1,57 @@
+package signalr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestResolveHeaders_NoProviderClonesHeaders(t *testing.T) {
+	shared := http.Header{"X-Custom": []string{"orig"}}
+	httpConn := &httpConnection{headers: func() http.Header { return shared }}
+
+	headers, err := resolveHeaders(context.Background(), httpConn)
+	if err != nil {
+		t.Fatalf("resolveHeaders() error = %v", err)
+	}
+	headers.Set("X-Custom", "mutated")
+
+	if got := shared.Get("X-Custom"); got != "orig" {
+		t.Fatalf("shared header mutated to %q, want %q", got, "orig")
+	}
+}
+
+func TestResolveHeaders_SetsBearerToken(t *testing.T) {
+	httpConn := &httpConnection{
+		accessTokenProvider: func(ctx context.Context) (string, error) {
+			return "tok123", nil
+		},
+	}
+
+	headers, err := resolveHeaders(context.Background(), httpConn)
+	if err != nil {
+		t.Fatalf("resolveHeaders() error = %v", err)
+	}
+	if got := headers.Get("Authorization"); got != "Bearer tok123" {
+		t.Fatalf("Authorization = %q, want %q", got, "Bearer tok123")
+	}
+}
+
+func TestResolveHeaders_ProviderErrorReturnsAuthError(t *testing.T) {
+	cause := errors.New("token expired")
+	httpConn := &httpConnection{
+		accessTokenProvider: func(ctx context.Context) (string, error) {
+			return "", cause
+		},
+	}
+
+	_, err := resolveHeaders(context.Background(), httpConn)
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("resolveHeaders() error = %v, want *AuthError", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatalf("resolveHeaders() error does not wrap %v", cause)
+	}
+}
@@ -0,0 +1,65 @@
+package signalr
+
+import "nhooyr.io/websocket"
+
+// CompressionMode controls whether and how per-message deflate (permessage-deflate) is
+// negotiated for the WebSocket transport.
+type CompressionMode int
+
+const (
+	// CompressionDisabled never advertises permessage-deflate. This is the default, and is
+	// recommended when pairing with NewMessagePackHubProtocol, whose payloads are already
+	// binary and usually too small for compression to pay off.
+	CompressionDisabled CompressionMode = iota
+	// CompressionContextTakeover keeps the deflate window across messages, trading memory for
+	// a better compression ratio on a stream of repetitive payloads.
+	CompressionContextTakeover
+	// CompressionNoContextTakeover resets the deflate window for every message, using less
+	// memory at the cost of compression ratio.
+	CompressionNoContextTakeover
+)
+
+func (m CompressionMode) nhooyrMode() websocket.CompressionMode {
+	switch m {
+	case CompressionContextTakeover:
+		return websocket.CompressionContextTakeover
+	case CompressionNoContextTakeover:
+		return websocket.CompressionNoContextTakeover
+	default:
+		return websocket.CompressionDisabled
+	}
+}
+
+// defaultCompressionThreshold is the minimum frame size, in bytes, used when
+// CompressionOptions.Threshold is nil.
+const defaultCompressionThreshold = 512
+
+// CompressionOptions configures per-message deflate negotiation for the default
+// nhooyr.io/websocket backed transport. Use WithCompression to apply it.
+type CompressionOptions struct {
+	Mode CompressionMode
+	// Threshold is the minimum frame size, in bytes, below which frames are sent uncompressed
+	// even when Mode enables compression. Defaults to 512 if nil; pass a pointer to 0 to compress
+	// every frame regardless of size.
+	Threshold *int
+}
+
+// threshold returns the effective compression threshold, applying defaultCompressionThreshold
+// when the caller didn't set one.
+func (o CompressionOptions) threshold() int {
+	if o.Threshold == nil {
+		return defaultCompressionThreshold
+	}
+	return *o.Threshold
+}
+
+// WithCompression enables permessage-deflate negotiation for the default WebSocket transport,
+// typically halving bandwidth for high-volume hubs pushing repetitive JSON payloads. It has no
+// effect when combined with WithWebSocketDialer, since compression then becomes the
+// responsibility of the plugged in dialer.
+func WithCompression(opts CompressionOptions) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.compression = &opts
+		return nil
+	}
+}
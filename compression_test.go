@@ -0,0 +1,40 @@
+package signalr
+
+import "testing"
+
+func TestCompressionOptions_ThresholdDefaultsWhenNil(t *testing.T) {
+	opts := CompressionOptions{Mode: CompressionContextTakeover}
+	if got := opts.threshold(); got != defaultCompressionThreshold {
+		t.Fatalf("threshold() = %v, want %v", got, defaultCompressionThreshold)
+	}
+}
+
+func TestCompressionOptions_ThresholdPreservesExplicitZero(t *testing.T) {
+	zero := 0
+	opts := CompressionOptions{Mode: CompressionContextTakeover, Threshold: &zero}
+	if got := opts.threshold(); got != 0 {
+		t.Fatalf("threshold() = %v, want 0", got)
+	}
+}
+
+func TestCompressionOptions_ThresholdPreservesExplicitValue(t *testing.T) {
+	custom := 2048
+	opts := CompressionOptions{Mode: CompressionContextTakeover, Threshold: &custom}
+	if got := opts.threshold(); got != custom {
+		t.Fatalf("threshold() = %v, want %v", got, custom)
+	}
+}
+
+func TestWithCompression_SetsHTTPConnectionField(t *testing.T) {
+	httpConn := &httpConnection{}
+	opt := WithCompression(CompressionOptions{Mode: CompressionNoContextTakeover})
+	if err := opt(httpConn); err != nil {
+		t.Fatalf("WithCompression() error = %v", err)
+	}
+	if httpConn.compression == nil {
+		t.Fatalf("compression = nil, want set")
+	}
+	if httpConn.compression.Mode != CompressionNoContextTakeover {
+		t.Fatalf("compression.Mode = %v, want CompressionNoContextTakeover", httpConn.compression.Mode)
+	}
+}
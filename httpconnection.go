@@ -8,8 +8,6 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
-
-	"nhooyr.io/websocket"
 )
 
 // Doer is the *http.Client interface
@@ -18,11 +16,22 @@ type Doer interface {
 }
 
 type httpConnection struct {
-	client      Doer
-	headers     func() http.Header
-	queryString func() string
+	client              Doer
+	headers             func() http.Header
+	queryString         func() string
+	reconnect           RetryPolicy
+	onReconnecting      func(error)
+	onReconnected       func(connectionID string)
+	preferredTransports []string
+	accessTokenProvider func(ctx context.Context) (string, error)
+	wsDialer            WebSocketDialer
+	compression         *CompressionOptions
 }
 
+// defaultTransportOrder is the order in which transports are tried when
+// WithPreferredTransports is not used.
+var defaultTransportOrder = []string{"WebSockets", "ServerSentEvents", "LongPolling"}
+
 // WithHTTPClient sets the http client used to connect to the signalR server
 func WithHTTPClient(client Doer) func(*httpConnection) error {
 	return func(c *httpConnection) error {
@@ -47,6 +56,76 @@ func WithQueryString(queryString func() string) func(*httpConnection) error {
 	}
 }
 
+// WithPreferredTransports overrides the order in which transports are tried against the
+// transfer formats advertised by the server's negotiate response. The first entry whose
+// transfer format is available wins. Valid entries are "WebSockets", "ServerSentEvents" and
+// "LongPolling".
+func WithPreferredTransports(transports []string) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.preferredTransports = transports
+		return nil
+	}
+}
+
+// WithAccessTokenProvider sets a function that is consulted for a bearer token before every
+// HTTP request (negotiate, Server-Sent Events, Long Polling), before every WebSocket dial and
+// again on every auto-reconnect attempt, allowing a token that expires mid-session to be
+// refreshed. The token populates the "Authorization: Bearer ..." header for HTTP/SSE/Long
+// Polling and the "access_token" query parameter for WebSockets. An error returned by provider
+// aborts the operation with a typed *AuthError.
+func WithAccessTokenProvider(provider func(ctx context.Context) (string, error)) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.accessTokenProvider = provider
+		return nil
+	}
+}
+
+// WithAutoReconnect enables automatic reconnection of the Connection returned by NewHTTPConnection.
+// When the underlying transport drops, the negotiate handshake is re-run and a new transport is
+// opened according to policy. Reads and writes on the Connection block until a new transport is
+// established or policy gives up, in which case they return an error wrapping the last dial error
+// (or, if no redial was made, the error that dropped the transport). An *AuthError from the
+// access token provider ends reconnecting at once and is returned as is.
+//
+// A Write that was in flight when the transport dropped is retried against the new transport
+// once reconnect succeeds, so the invocation it carries is not lost. There is no broader replay
+// buffer: invocations already flushed to the old transport before it dropped are not reissued,
+// since SignalR gives the client no way to know whether the server actually processed them.
+func WithAutoReconnect(policy RetryPolicy) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.reconnect = policy
+		return nil
+	}
+}
+
+// WithOnReconnecting sets a callback invoked with the error that caused the transport to drop,
+// right before a reconnect attempt starts. Requires WithAutoReconnect.
+func WithOnReconnecting(onReconnecting func(error)) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.onReconnecting = onReconnecting
+		return nil
+	}
+}
+
+// WithOnReconnected sets a callback invoked with the new connection ID once a reconnect attempt
+// succeeds. Requires WithAutoReconnect.
+func WithOnReconnected(onReconnected func(connectionID string)) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.onReconnected = onReconnected
+		return nil
+	}
+}
+
+// WithWebSocketDialer sets the WebSocketDialer used to open the WebSocket transport, allowing
+// an alternate client (e.g. github.com/gorilla/websocket via signalr/wsgorilla) to replace the
+// default nhooyr.io/websocket based dialer.
+func WithWebSocketDialer(dialer WebSocketDialer) func(*httpConnection) error {
+	return func(c *httpConnection) error {
+		c.wsDialer = dialer
+		return nil
+	}
+}
+
 // NewHTTPConnection creates a signalR HTTP Connection for usage with a Client.
 // ctx can be used to cancel the SignalR negotiation during the creation of the Connection
 // but not the Connection itself.
@@ -65,6 +144,25 @@ func NewHTTPConnection(ctx context.Context, address string, options ...func(*htt
 		httpConn.client = &http.Client{}
 	}
 
+	if httpConn.wsDialer == nil {
+		httpConn.wsDialer = nhooyrWebSocketDialer{compression: httpConn.compression}
+	}
+
+	conn, err := dialHTTPConnection(ctx, address, httpConn)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpConn.reconnect != nil {
+		conn = newReconnectingConnection(address, httpConn, conn)
+	}
+
+	return conn, nil
+}
+
+// dialHTTPConnection runs the negotiate handshake against address and opens the best available
+// transport. It is used both for the initial connect and for every reconnect attempt.
+func dialHTTPConnection(ctx context.Context, address string, httpConn *httpConnection) (Connection, error) {
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/negotiate", address), nil)
 	if err != nil {
 		return nil, err
@@ -74,8 +172,12 @@ func NewHTTPConnection(ctx context.Context, address string, options ...func(*htt
 		req.URL.RawQuery = httpConn.queryString()
 	}
 
-	if httpConn.headers != nil {
-		req.Header = httpConn.headers()
+	if httpConn.headers != nil || httpConn.accessTokenProvider != nil {
+		headers, err := resolveHeaders(ctx, httpConn)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = headers
 	}
 
 	resp, err := httpConn.client.Do(req)
@@ -112,60 +214,84 @@ func NewHTTPConnection(ctx context.Context, address string, options ...func(*htt
 	reqURL.RawQuery = q.Encode()
 
 	// Select the best connection
+	transports := defaultTransportOrder
+	if len(httpConn.preferredTransports) > 0 {
+		transports = httpConn.preferredTransports
+	}
+
 	var conn Connection
-	switch {
-	// case nr.getTransferFormats("WebTransports") != nil:
-	case nr.getTransferFormats("WebSockets") != nil:
-		wsURL := reqURL
-
-		// switch to wss for secure connection
-		if reqURL.Scheme == "https" {
-			wsURL.Scheme = "wss"
-		} else {
-			wsURL.Scheme = "ws"
+	for _, transport := range transports {
+		if nr.getTransferFormats(transport) == nil {
+			continue
 		}
 
-		opts := &websocket.DialOptions{}
-		if httpConn.headers != nil {
-			headers := httpConn.headers()
-			accessToken := ""
-			if headers.Get("Authorization") != "" {
-				accessToken = strings.ReplaceAll(headers.Get("Authorization"), "Bearer ", "")
-				headers.Del("Authorization")
+		switch transport {
+		case "WebSockets":
+			wsURL := reqURL
+
+			// switch to wss for secure connection
+			if reqURL.Scheme == "https" {
+				wsURL.Scheme = "wss"
+			} else {
+				wsURL.Scheme = "ws"
 			}
-			opts.HTTPHeader = httpConn.headers()
 
-			q := wsURL.Query()
-			q.Set("access_token", accessToken)
-			wsURL.RawQuery = q.Encode()
-		}
+			var wsHeader http.Header
+			if httpConn.headers != nil || httpConn.accessTokenProvider != nil {
+				headers, err := resolveHeaders(ctx, httpConn)
+				if err != nil {
+					return nil, err
+				}
+				accessToken := ""
+				if headers.Get("Authorization") != "" {
+					accessToken = strings.ReplaceAll(headers.Get("Authorization"), "Bearer ", "")
+					headers.Del("Authorization")
+				}
+				wsHeader = headers
 
-		ws, _, err := websocket.Dial(ctx, wsURL.String(), opts)
-		if err != nil {
-			return nil, err
-		}
+				q := wsURL.Query()
+				q.Set("access_token", accessToken)
+				wsURL.RawQuery = q.Encode()
+			}
 
-		conn = newWebSocketConnection(context.Background(), nr.ConnectionID, ws)
+			ws, err := httpConn.wsDialer.Dial(ctx, wsURL.String(), wsHeader)
+			if err != nil {
+				return nil, err
+			}
 
-	case nr.getTransferFormats("ServerSentEvents") != nil:
-		req, err := http.NewRequest("GET", reqURL.String(), nil)
-		if err != nil {
-			return nil, err
-		}
+			conn = newWebSocketConnection(context.Background(), nr.ConnectionID, ws)
 
-		if httpConn.headers != nil {
-			req.Header = httpConn.headers()
-		}
-		req.Header.Set("Accept", "text/event-stream")
+		case "ServerSentEvents":
+			req, err := http.NewRequest("GET", reqURL.String(), nil)
+			if err != nil {
+				return nil, err
+			}
 
-		resp, err := httpConn.client.Do(req)
-		if err != nil {
-			return nil, err
+			if httpConn.headers != nil || httpConn.accessTokenProvider != nil {
+				headers, err := resolveHeaders(ctx, httpConn)
+				if err != nil {
+					return nil, err
+				}
+				req.Header = headers
+			}
+			req.Header.Set("Accept", "text/event-stream")
+
+			resp, err := httpConn.client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+
+			conn, err = newClientSSEConnection(address, nr.ConnectionID, resp.Body)
+			if err != nil {
+				return nil, err
+			}
+
+		case "LongPolling":
+			conn = newLongPollingConnection(reqURL.String(), nr.ConnectionID, httpConn)
 		}
 
-		conn, err = newClientSSEConnection(address, nr.ConnectionID, resp.Body)
-		if err != nil {
-			return nil, err
+		if conn != nil {
+			break
 		}
 	}
 
@@ -3,6 +3,8 @@ package signalr
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"sync"
 	"sync/atomic"
 )
 
@@ -12,6 +14,9 @@ type hubConnection interface {
 	ConnectionID() string
 	Receive() (interface{}, error)
 	SendInvocation(target string, args ...interface{}) (sendOnlyHubInvocationMessage, error)
+	SendStreamInvocation(target string, args ...interface{}) (streamInvocationMessage, error)
+	SendCancelInvocation(invocationID string) (cancelInvocationMessage, error)
+	StreamItems(target string, args ...interface{}) (items <-chan interface{}, cancel func() error, err error)
 	StreamItem(id string, item interface{}) (streamItemMessage, error)
 	Completion(id string, result interface{}, error string) (completionMessage, error)
 	Close(error string) (closeMessage, error)
@@ -30,6 +35,7 @@ func newHubConnection(connection Connection, protocol HubProtocol, maximumReceiv
 		items:                     make(map[string]interface{}),
 		context:                   ctx,
 		abort:                     abort,
+		streams:                   make(map[string]*streamSubscription),
 	}
 }
 
@@ -41,6 +47,10 @@ type defaultHubConnection struct {
 	items                     map[string]interface{}
 	context                   context.Context
 	abort                     context.CancelFunc
+	lastInvocationID          int64
+
+	streamsMu sync.Mutex
+	streams   map[string]*streamSubscription
 }
 
 func (c *defaultHubConnection) Items() map[string]interface{} {
@@ -109,9 +119,19 @@ func (c *defaultHubConnection) Receive() (interface{}, error) {
 	case <-c.context.Done():
 		// Wait for ReadMessage to return
 		<-e
+		c.closeAllStreams()
 		return nil, c.context.Err()
 	case err := <-e:
-		return <-m, err
+		message := <-m
+		if err != nil {
+			c.closeAllStreams()
+			return message, err
+		}
+		// Receive() is the only reader of the underlying Connection, so it is also the single
+		// fan-out point for StreamItems: every message is offered to dispatchStreamMessage in
+		// addition to being returned here as usual.
+		c.dispatchStreamMessage(message)
+		return message, nil
 	}
 }
 
@@ -124,6 +144,29 @@ func (c *defaultHubConnection) SendInvocation(target string, args ...interface{}
 	return invocationMessage, c.writeMessage(invocationMessage)
 }
 
+func (c *defaultHubConnection) SendStreamInvocation(target string, args ...interface{}) (streamInvocationMessage, error) {
+	var streamInvocationMessage = streamInvocationMessage{
+		Type:         4,
+		InvocationID: c.newInvocationID(),
+		Target:       target,
+		Arguments:    args,
+	}
+	return streamInvocationMessage, c.writeMessage(streamInvocationMessage)
+}
+
+func (c *defaultHubConnection) SendCancelInvocation(invocationID string) (cancelInvocationMessage, error) {
+	var cancelInvocationMessage = cancelInvocationMessage{
+		Type:         5,
+		InvocationID: invocationID,
+	}
+	return cancelInvocationMessage, c.writeMessage(cancelInvocationMessage)
+}
+
+// newInvocationID returns a new, connection-unique invocation ID for a streamInvocationMessage.
+func (c *defaultHubConnection) newInvocationID() string {
+	return fmt.Sprint(atomic.AddInt64(&c.lastInvocationID, 1))
+}
+
 func (c *defaultHubConnection) StreamItem(id string, item interface{}) (streamItemMessage, error) {
 	var streamItemMessage = streamItemMessage{
 		Type:         2,
@@ -0,0 +1,138 @@
+package signalr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// longPollingConnection is a Connection that polls requestURL with a long hanging GET for
+// reads and issues a POST per write, as used by the SignalR Long Polling transport.
+type longPollingConnection struct {
+	ctx          context.Context
+	address      string
+	connectionID string
+	httpConn     *httpConnection
+
+	mu   sync.Mutex
+	body io.ReadCloser
+}
+
+// newLongPollingConnection creates a Connection for the Long Polling transport. address is the
+// negotiated request URL, already carrying the "id" query parameter. The connection's polls and
+// writes run under their own background context, decoupled from the (possibly short-lived)
+// context used to negotiate it — the same decoupling the WebSocket and SSE transports apply by
+// running their open connections under context.Background().
+func newLongPollingConnection(address string, connectionID string, httpConn *httpConnection) *longPollingConnection {
+	return &longPollingConnection{
+		ctx:          context.Background(),
+		address:      address,
+		connectionID: connectionID,
+		httpConn:     httpConn,
+	}
+}
+
+func (l *longPollingConnection) ConnectionID() string {
+	return l.connectionID
+}
+
+// Read blocks on a long hanging GET until data, EOF (204 No Content, meaning the server closed
+// the connection) or an error is available.
+func (l *longPollingConnection) Read(p []byte) (int, error) {
+	for {
+		l.mu.Lock()
+		body := l.body
+		l.mu.Unlock()
+
+		if body == nil {
+			if err := l.poll(); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		n, err := body.Read(p)
+		if n > 0 {
+			return n, nil
+		}
+		if err == io.EOF {
+			_ = body.Close()
+			l.mu.Lock()
+			l.body = nil
+			l.mu.Unlock()
+			continue
+		}
+		return 0, err
+	}
+}
+
+// poll issues the long hanging GET and stores the response body for Read to drain.
+func (l *longPollingConnection) poll() error {
+	req, err := http.NewRequestWithContext(l.ctx, "GET", l.address, nil)
+	if err != nil {
+		return err
+	}
+	headers, err := resolveHeaders(l.ctx, l.httpConn)
+	if err != nil {
+		return err
+	}
+	req.Header = headers
+
+	resp, err := l.httpConn.client.Do(req)
+	if err != nil {
+		select {
+		case <-l.ctx.Done():
+			return l.ctx.Err()
+		default:
+			return err
+		}
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		_ = resp.Body.Close()
+		return io.EOF
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return fmt.Errorf("long polling GET %v -> %v", req.URL.String(), resp.Status)
+	}
+
+	l.mu.Lock()
+	l.body = resp.Body
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *longPollingConnection) Write(p []byte) (int, error) {
+	reqURL, err := url.Parse(l.address)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(l.ctx, "POST", reqURL.String(), bytes.NewReader(p))
+	if err != nil {
+		return 0, err
+	}
+	headers, err := resolveHeaders(l.ctx, l.httpConn)
+	if err != nil {
+		return 0, err
+	}
+	req.Header = headers
+
+	resp, err := l.httpConn.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return 0, fmt.Errorf("long polling POST %v -> %v", req.URL.String(), resp.Status)
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,95 @@
+package signalr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLongPollingConnection_ReadPolls(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method %v", r.Method)
+		}
+		gets++
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	httpConn := &httpConnection{client: server.Client()}
+	conn := newLongPollingConnection(server.URL, "conn-1", httpConn)
+
+	buf := make([]byte, 16)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if string(buf[:n]) != "hello" {
+		t.Fatalf("Read() = %q, want %q", buf[:n], "hello")
+	}
+	if gets != 1 {
+		t.Fatalf("got %v GETs, want 1", gets)
+	}
+}
+
+func TestLongPollingConnection_ReadNoContentReturnsEOF(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpConn := &httpConnection{client: server.Client()}
+	conn := newLongPollingConnection(server.URL, "conn-1", httpConn)
+
+	buf := make([]byte, 16)
+	_, err := conn.Read(buf)
+	if err != io.EOF {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestLongPollingConnection_Write(t *testing.T) {
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method %v", r.Method)
+		}
+		var err error
+		posted, err = io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	httpConn := &httpConnection{client: server.Client()}
+	conn := newLongPollingConnection(server.URL, "conn-1", httpConn)
+
+	n, err := conn.Write([]byte("payload"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if n != len("payload") {
+		t.Fatalf("Write() n = %v, want %v", n, len("payload"))
+	}
+	if string(posted) != "payload" {
+		t.Fatalf("server received %q, want %q", posted, "payload")
+	}
+}
+
+func TestLongPollingConnection_WriteErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	httpConn := &httpConnection{client: server.Client()}
+	conn := newLongPollingConnection(server.URL, "conn-1", httpConn)
+
+	if _, err := conn.Write([]byte("payload")); err == nil {
+		t.Fatalf("Write() error = nil, want non-nil")
+	}
+}
@@ -0,0 +1,236 @@
+package signalr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// NewMessagePackHubProtocol creates a HubProtocol implementation for the SignalR MessagePack
+// sub-protocol, compatible with the wire format used by @microsoft/signalr-protocol-msgpack.
+// Messages are framed as a VarInt length prefix followed by a msgpack encoded array whose
+// first element is the message type.
+func NewMessagePackHubProtocol() HubProtocol {
+	return &messagePackHubProtocol{}
+}
+
+type messagePackHubProtocol struct {
+	dbg Logger
+}
+
+func (m *messagePackHubProtocol) Name() string {
+	return "messagepack"
+}
+
+func (m *messagePackHubProtocol) TransferMode() TransferMode {
+	return BinaryTransferMode
+}
+
+func (m *messagePackHubProtocol) setDebugLogger(logger Logger) {
+	m.dbg = logger
+}
+
+// ReadMessage reads one VarInt length prefixed messagepack frame from buf.
+// If buf does not yet hold a complete frame, complete is false and buf is left untouched.
+func (m *messagePackHubProtocol) ReadMessage(buf *bytes.Buffer) (message interface{}, complete bool, err error) {
+	data := buf.Bytes()
+
+	length, headerLen, ok := readVarUint(data)
+	if !ok {
+		return nil, false, nil
+	}
+	if uint64(len(data)-headerLen) < length {
+		return nil, false, nil
+	}
+
+	frame := data[headerLen : uint64(headerLen)+length]
+	buf.Next(headerLen + int(length))
+
+	var raw []msgpack.RawMessage
+	if err := msgpack.Unmarshal(frame, &raw); err != nil {
+		return nil, true, err
+	}
+	if len(raw) == 0 {
+		return nil, true, fmt.Errorf("messagepack: empty message array")
+	}
+
+	var msgType int
+	if err := msgpack.Unmarshal(raw[0], &msgType); err != nil {
+		return nil, true, err
+	}
+
+	switch msgType {
+	case 1, 4:
+		if len(raw) < 5 {
+			return nil, true, fmt.Errorf("messagepack: short invocation message")
+		}
+		var invocationID string
+		if err := msgpack.Unmarshal(raw[2], &invocationID); err != nil {
+			return nil, true, err
+		}
+		var target string
+		if err := msgpack.Unmarshal(raw[3], &target); err != nil {
+			return nil, true, err
+		}
+		var args []msgpack.RawMessage
+		if err := msgpack.Unmarshal(raw[4], &args); err != nil {
+			return nil, true, err
+		}
+		arguments := make([]interface{}, len(args))
+		for i, a := range args {
+			arguments[i] = a
+		}
+		if msgType == 4 {
+			return streamInvocationMessage{Type: 4, InvocationID: invocationID, Target: target, Arguments: arguments}, true, nil
+		}
+		if invocationID == "" {
+			return sendOnlyHubInvocationMessage{Type: 1, Target: target, Arguments: arguments}, true, nil
+		}
+		return invocationMessage{Type: 1, InvocationID: invocationID, Target: target, Arguments: arguments}, true, nil
+	case 2:
+		if len(raw) < 4 {
+			return nil, true, fmt.Errorf("messagepack: short stream item message")
+		}
+		var invocationID string
+		if err := msgpack.Unmarshal(raw[2], &invocationID); err != nil {
+			return nil, true, err
+		}
+		return streamItemMessage{Type: 2, InvocationID: invocationID, Item: raw[3]}, true, nil
+	case 3:
+		if len(raw) < 4 {
+			return nil, true, fmt.Errorf("messagepack: short completion message")
+		}
+		var invocationID string
+		if err := msgpack.Unmarshal(raw[2], &invocationID); err != nil {
+			return nil, true, err
+		}
+		var resultKind int
+		if err := msgpack.Unmarshal(raw[3], &resultKind); err != nil {
+			return nil, true, err
+		}
+		completion := completionMessage{Type: 3, InvocationID: invocationID}
+		switch resultKind {
+		case 1: // error result
+			if len(raw) < 5 {
+				return nil, true, fmt.Errorf("messagepack: missing completion error")
+			}
+			if err := msgpack.Unmarshal(raw[4], &completion.Error); err != nil {
+				return nil, true, err
+			}
+		case 3: // non-void result
+			if len(raw) < 5 {
+				return nil, true, fmt.Errorf("messagepack: missing completion result")
+			}
+			completion.Result = raw[4]
+		}
+		return completion, true, nil
+	case 5:
+		if len(raw) < 3 {
+			return nil, true, fmt.Errorf("messagepack: short cancel invocation message")
+		}
+		var invocationID string
+		if err := msgpack.Unmarshal(raw[2], &invocationID); err != nil {
+			return nil, true, err
+		}
+		return cancelInvocationMessage{Type: 5, InvocationID: invocationID}, true, nil
+	case 6:
+		return hubMessage{Type: 6}, true, nil
+	case 7:
+		close := closeMessage{Type: 7}
+		if len(raw) > 1 {
+			_ = msgpack.Unmarshal(raw[1], &close.Error)
+		}
+		if len(raw) > 2 {
+			_ = msgpack.Unmarshal(raw[2], &close.AllowReconnect)
+		}
+		return close, true, nil
+	default:
+		return nil, true, fmt.Errorf("messagepack: unknown message type %v", msgType)
+	}
+}
+
+// WriteMessage encodes message as a msgpack array and writes it to writer, prefixed with its
+// VarInt encoded length.
+func (m *messagePackHubProtocol) WriteMessage(message interface{}, writer io.Writer) error {
+	var arr []interface{}
+
+	switch msg := message.(type) {
+	case invocationMessage:
+		arr = []interface{}{1, map[string]string{}, msg.InvocationID, msg.Target, msg.Arguments, nil}
+	case sendOnlyHubInvocationMessage:
+		// invocationId is nil, not "", for a fire-and-forget invocation: that's what real
+		// ASP.NET Core servers send and what ReadMessage's invocationID == "" check above expects.
+		arr = []interface{}{1, map[string]string{}, nil, msg.Target, msg.Arguments, nil}
+	case streamInvocationMessage:
+		arr = []interface{}{4, map[string]string{}, msg.InvocationID, msg.Target, msg.Arguments, nil}
+	case streamItemMessage:
+		arr = []interface{}{2, map[string]string{}, msg.InvocationID, msg.Item}
+	case completionMessage:
+		switch {
+		case msg.Error != "":
+			arr = []interface{}{3, map[string]string{}, msg.InvocationID, 1, msg.Error}
+		case msg.Result != nil:
+			arr = []interface{}{3, map[string]string{}, msg.InvocationID, 3, msg.Result}
+		default:
+			arr = []interface{}{3, map[string]string{}, msg.InvocationID, 2}
+		}
+	case cancelInvocationMessage:
+		arr = []interface{}{5, map[string]string{}, msg.InvocationID}
+	case hubMessage:
+		arr = []interface{}{msg.Type}
+	case closeMessage:
+		arr = []interface{}{7, msg.Error, msg.AllowReconnect}
+	default:
+		return fmt.Errorf("messagepack: unsupported message type %T", message)
+	}
+
+	frame, err := msgpack.Marshal(arr)
+	if err != nil {
+		return err
+	}
+
+	var header bytes.Buffer
+	writeVarUint(&header, uint64(len(frame)))
+
+	if _, err := writer.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err = writer.Write(frame)
+	return err
+}
+
+func (m *messagePackHubProtocol) UnmarshalArgument(argument interface{}, value interface{}) error {
+	raw, ok := argument.(msgpack.RawMessage)
+	if !ok {
+		return fmt.Errorf("messagepack: argument is not a msgpack.RawMessage")
+	}
+	return msgpack.Unmarshal(raw, value)
+}
+
+// writeVarUint writes v as a 7 bit VarInt, matching the length prefix used by the
+// SignalR messagepack wire format.
+func writeVarUint(w *bytes.Buffer, v uint64) {
+	for v >= 0x80 {
+		w.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	w.WriteByte(byte(v))
+}
+
+// readVarUint reads a 7 bit VarInt from the start of data. ok is false if data does not yet
+// contain a complete VarInt.
+func readVarUint(data []byte) (value uint64, n int, ok bool) {
+	var shift uint
+	for n < len(data) {
+		b := data[n]
+		value |= uint64(b&0x7f) << shift
+		n++
+		if b&0x80 == 0 {
+			return value, n, true
+		}
+		shift += 7
+	}
+	return 0, 0, false
+}
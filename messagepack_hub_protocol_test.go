@@ -0,0 +1,163 @@
+package signalr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// pingFrame is the captured wire bytes @microsoft/signalr-protocol-msgpack sends for a ping
+// message: a 1-byte VarInt length prefix (2) followed by the msgpack array [6].
+var pingFrame = []byte{0x02, 0x91, 0x06}
+
+func TestMessagePackHubProtocol_ReadMessage_Ping(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	buf := bytes.NewBuffer(pingFrame)
+
+	message, complete, err := protocol.ReadMessage(buf)
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if !complete {
+		t.Fatalf("ReadMessage() complete = false, want true")
+	}
+	if msg, ok := message.(hubMessage); !ok || msg.Type != 6 {
+		t.Fatalf("ReadMessage() = %#v, want hubMessage{Type: 6}", message)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("ReadMessage() left %v unread bytes, want 0", buf.Len())
+	}
+}
+
+func TestMessagePackHubProtocol_ReadMessage_Incomplete(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	// Only the length prefix and the first byte of the frame have arrived so far.
+	buf := bytes.NewBuffer(pingFrame[:2])
+
+	message, complete, err := protocol.ReadMessage(buf)
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if complete {
+		t.Fatalf("ReadMessage() complete = true, want false")
+	}
+	if message != nil {
+		t.Fatalf("ReadMessage() = %#v, want nil", message)
+	}
+}
+
+func TestMessagePackHubProtocol_WriteMessage_Ping(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	var buf bytes.Buffer
+
+	if err := protocol.WriteMessage(hubMessage{Type: 6}, &buf); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), pingFrame) {
+		t.Fatalf("WriteMessage() = % x, want % x", buf.Bytes(), pingFrame)
+	}
+}
+
+func TestMessagePackHubProtocol_RoundTripInvocation(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	original := invocationMessage{
+		Type:         1,
+		InvocationID: "42",
+		Target:       "Send",
+		Arguments:    []interface{}{"hello", 1},
+	}
+
+	var buf bytes.Buffer
+	if err := protocol.WriteMessage(original, &buf); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+
+	message, complete, err := protocol.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if !complete {
+		t.Fatalf("ReadMessage() complete = false, want true")
+	}
+
+	invocation, ok := message.(invocationMessage)
+	if !ok {
+		t.Fatalf("ReadMessage() = %#v, want invocationMessage", message)
+	}
+	if invocation.InvocationID != original.InvocationID || invocation.Target != original.Target {
+		t.Fatalf("ReadMessage() = %#v, want InvocationID=%v Target=%v", invocation, original.InvocationID, original.Target)
+	}
+}
+
+func TestMessagePackHubProtocol_RoundTripCompletionError(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	original := completionMessage{
+		Type:         3,
+		InvocationID: "7",
+		Error:        "boom",
+	}
+
+	var buf bytes.Buffer
+	if err := protocol.WriteMessage(original, &buf); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+
+	message, _, err := protocol.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+
+	completion, ok := message.(completionMessage)
+	if !ok {
+		t.Fatalf("ReadMessage() = %#v, want completionMessage", message)
+	}
+	if completion.InvocationID != original.InvocationID || completion.Error != original.Error {
+		t.Fatalf("ReadMessage() = %#v, want %#v", completion, original)
+	}
+}
+
+func TestMessagePackHubProtocol_WriteMessage_SendOnlyInvocationIDIsNil(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	original := sendOnlyHubInvocationMessage{
+		Type:      1,
+		Target:    "Send",
+		Arguments: []interface{}{"hello"},
+	}
+
+	var buf bytes.Buffer
+	if err := protocol.WriteMessage(original, &buf); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+
+	var arr []interface{}
+	frame := buf.Bytes()
+	_, headerLen, ok := readVarUint(frame)
+	if !ok {
+		t.Fatalf("readVarUint() failed on %x", frame)
+	}
+	if err := msgpack.Unmarshal(frame[headerLen:], &arr); err != nil {
+		t.Fatalf("msgpack.Unmarshal() error = %v", err)
+	}
+	if arr[2] != nil {
+		t.Fatalf("invocationId element = %#v, want nil", arr[2])
+	}
+
+	message, complete, err := protocol.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if !complete {
+		t.Fatalf("ReadMessage() complete = false, want true")
+	}
+	if _, ok := message.(sendOnlyHubInvocationMessage); !ok {
+		t.Fatalf("ReadMessage() = %#v, want sendOnlyHubInvocationMessage", message)
+	}
+}
+
+func TestMessagePackHubProtocol_TransferMode(t *testing.T) {
+	protocol := NewMessagePackHubProtocol()
+	if mode := protocol.TransferMode(); mode != BinaryTransferMode {
+		t.Fatalf("TransferMode() = %v, want BinaryTransferMode", mode)
+	}
+}
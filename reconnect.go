@@ -0,0 +1,176 @@
+package signalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RetryPolicy determines how long to wait before the next reconnect attempt made by a
+// Connection created through WithAutoReconnect. previousRetryCount is the number of attempts
+// already made since the transport dropped, starting at 0. Returning false stops reconnecting.
+type RetryPolicy interface {
+	NextRetryDelay(previousRetryCount int) (delay time.Duration, retry bool)
+}
+
+// DefaultRetryPolicy waits 0s, 2s, 10s and 30s between reconnect attempts and then gives up,
+// mirroring the default retry policy of the official JS and .NET clients.
+type DefaultRetryPolicy struct {
+	delays []time.Duration
+}
+
+// NewDefaultRetryPolicy creates a DefaultRetryPolicy.
+func NewDefaultRetryPolicy() *DefaultRetryPolicy {
+	return &DefaultRetryPolicy{
+		delays: []time.Duration{0, 2 * time.Second, 10 * time.Second, 30 * time.Second},
+	}
+}
+
+func (p *DefaultRetryPolicy) NextRetryDelay(previousRetryCount int) (time.Duration, bool) {
+	if previousRetryCount >= len(p.delays) {
+		return 0, false
+	}
+	return p.delays[previousRetryCount], true
+}
+
+// reconnectAttempt tracks a single in-flight redial so concurrent callers of reconnect wait for
+// it instead of each starting their own.
+type reconnectAttempt struct {
+	done chan struct{}
+	err  error
+}
+
+// reconnectingConnection wraps a Connection dialed by dialHTTPConnection and transparently
+// redials it with the configured RetryPolicy whenever a Read or Write fails. Reads and writes
+// block for the duration of a reconnect attempt instead of returning the underlying error.
+//
+// The redial loop uses its own context, decoupled from the context the caller passed to
+// NewHTTPConnection for the initial negotiate (which, per NewHTTPConnection's doc comment, does
+// not govern the Connection's lifetime) — the same decoupling the WebSocket and SSE transports
+// already apply by running their open connections under context.Background().
+type reconnectingConnection struct {
+	mu         sync.RWMutex
+	ctx        context.Context
+	address    string
+	httpConn   *httpConnection
+	current    Connection
+	retryCount int
+	attempt    *reconnectAttempt
+}
+
+func newReconnectingConnection(address string, httpConn *httpConnection, initial Connection) *reconnectingConnection {
+	return &reconnectingConnection{
+		ctx:      context.Background(),
+		address:  address,
+		httpConn: httpConn,
+		current:  initial,
+	}
+}
+
+func (r *reconnectingConnection) ConnectionID() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.ConnectionID()
+}
+
+func (r *reconnectingConnection) Read(p []byte) (int, error) {
+	for {
+		r.mu.RLock()
+		conn := r.current
+		r.mu.RUnlock()
+
+		n, err := conn.Read(p)
+		if err == nil {
+			return n, nil
+		}
+		if rerr := r.reconnect(err); rerr != nil {
+			return 0, rerr
+		}
+	}
+}
+
+// Write retries p against the newly dialed transport if the in-flight attempt fails, so the
+// invocation it carries survives a reconnect. It cannot replay invocations from earlier Write
+// calls that already succeeded against the dropped transport; SignalR has no ack the client could
+// use to know those need resending.
+func (r *reconnectingConnection) Write(p []byte) (int, error) {
+	for {
+		r.mu.RLock()
+		conn := r.current
+		r.mu.RUnlock()
+
+		n, err := conn.Write(p)
+		if err == nil {
+			return n, nil
+		}
+		if rerr := r.reconnect(err); rerr != nil {
+			return 0, rerr
+		}
+	}
+}
+
+// reconnect blocks until dialHTTPConnection succeeds or the RetryPolicy gives up, in which case
+// it returns an error wrapping the last dial error (cause, if no redial was made); an *AuthError
+// from a redial is returned at once. Concurrent callers (e.g. the Receive() reader racing a
+// SendInvocation writer) join the single in-flight attempt instead of each redialing.
+func (r *reconnectingConnection) reconnect(cause error) error {
+	r.mu.Lock()
+	if attempt := r.attempt; attempt != nil {
+		r.mu.Unlock()
+		<-attempt.done
+		return attempt.err
+	}
+	attempt := &reconnectAttempt{done: make(chan struct{})}
+	r.attempt = attempt
+	r.mu.Unlock()
+
+	err := r.runReconnect(cause)
+
+	r.mu.Lock()
+	attempt.err = err
+	r.attempt = nil
+	r.mu.Unlock()
+	close(attempt.done)
+
+	return err
+}
+
+// runReconnect performs the actual redial loop. It is only ever run by the single goroutine that
+// owns the current reconnectAttempt, so retryCount needs no separate locking here.
+func (r *reconnectingConnection) runReconnect(cause error) error {
+	if r.httpConn.onReconnecting != nil {
+		r.httpConn.onReconnecting(cause)
+	}
+
+	for {
+		delay, retry := r.httpConn.reconnect.NextRetryDelay(r.retryCount)
+		if !retry {
+			return fmt.Errorf("signalr: reconnect failed after %v attempts: %w", r.retryCount, cause)
+		}
+
+		time.Sleep(delay)
+
+		conn, err := dialHTTPConnection(r.ctx, r.address, r.httpConn)
+		r.retryCount++
+		if err != nil {
+			var authErr *AuthError
+			if errors.As(err, &authErr) {
+				return err
+			}
+			cause = err
+			continue
+		}
+
+		r.mu.Lock()
+		r.current = conn
+		r.mu.Unlock()
+		r.retryCount = 0
+
+		if r.httpConn.onReconnected != nil {
+			r.httpConn.onReconnected(conn.ConnectionID())
+		}
+		return nil
+	}
+}
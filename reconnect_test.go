@@ -0,0 +1,145 @@
+package signalr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubConnection is a minimal Connection whose Read/Write are scripted for a test.
+type stubConnection struct {
+	id       string
+	readErr  error
+	writeErr error
+
+	mu        sync.Mutex
+	writeSeen [][]byte
+}
+
+func (s *stubConnection) ConnectionID() string { return s.id }
+
+func (s *stubConnection) Read(p []byte) (int, error) {
+	if s.readErr != nil {
+		return 0, s.readErr
+	}
+	return 0, nil
+}
+
+func (s *stubConnection) Write(p []byte) (int, error) {
+	if s.writeErr != nil {
+		return 0, s.writeErr
+	}
+	s.mu.Lock()
+	s.writeSeen = append(s.writeSeen, append([]byte(nil), p...))
+	s.mu.Unlock()
+	return len(p), nil
+}
+
+// immediateRetryPolicy always allows up to maxAttempts retries with no delay, so tests don't sleep.
+type immediateRetryPolicy struct {
+	maxAttempts int
+}
+
+func (p *immediateRetryPolicy) NextRetryDelay(previousRetryCount int) (time.Duration, bool) {
+	if previousRetryCount >= p.maxAttempts {
+		return 0, false
+	}
+	return 0, true
+}
+
+// newNegotiateServer starts an httptest server answering /negotiate so dialHTTPConnection can
+// redial against it using the Long Polling transport, which needs no further network calls at
+// dial time.
+func newNegotiateServer(t *testing.T, connectionID string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"connectionId":%q,"availableTransports":[{"transport":"LongPolling","transferFormats":["Text"]}]}`, connectionID)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReconnectingConnection_WriteRetriesAfterReconnect(t *testing.T) {
+	server := newNegotiateServer(t, "new-conn")
+	failing := &stubConnection{id: "old", writeErr: errors.New("connection reset")}
+
+	httpConn := &httpConnection{
+		client:              server.Client(),
+		reconnect:           &immediateRetryPolicy{maxAttempts: 1},
+		preferredTransports: []string{"LongPolling"},
+	}
+	r := newReconnectingConnection(server.URL, httpConn, failing)
+
+	n, err := r.Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if n != len("hello") {
+		t.Fatalf("Write() n = %v, want %v", n, len("hello"))
+	}
+	if r.ConnectionID() != "new-conn" {
+		t.Fatalf("ConnectionID() = %v, want new-conn", r.ConnectionID())
+	}
+}
+
+func TestReconnectingConnection_ReconnectSingleFlight(t *testing.T) {
+	var negotiates int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&negotiates, 1)
+		time.Sleep(10 * time.Millisecond)
+		_, _ = fmt.Fprint(w, `{"connectionId":"new-conn","availableTransports":[{"transport":"LongPolling","transferFormats":["Text"]}]}`)
+	}))
+	defer server.Close()
+
+	failing := &stubConnection{id: "old", readErr: errors.New("connection reset")}
+	httpConn := &httpConnection{
+		client:              server.Client(),
+		reconnect:           &immediateRetryPolicy{maxAttempts: 1},
+		preferredTransports: []string{"LongPolling"},
+	}
+	r := newReconnectingConnection(server.URL, httpConn, failing)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = r.reconnect(errors.New("boom"))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("reconnect()[%v] error = %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&negotiates); got != 1 {
+		t.Fatalf("negotiate called %v times, want 1", got)
+	}
+}
+
+func TestReconnectingConnection_GivesUpAfterPolicyExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	failing := &stubConnection{id: "old", readErr: errors.New("connection reset")}
+	httpConn := &httpConnection{
+		client:    server.Client(),
+		reconnect: &immediateRetryPolicy{maxAttempts: 2},
+	}
+	r := newReconnectingConnection(server.URL, httpConn, failing)
+
+	buf := make([]byte, 1)
+	if _, err := r.Read(buf); err == nil {
+		t.Fatalf("Read() error = nil, want non-nil")
+	}
+}
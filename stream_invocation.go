@@ -0,0 +1,113 @@
+package signalr
+
+// streamInvocationMessage is message type 4, sent by a client to start a server streaming
+// invocation. The server answers with zero or more streamItemMessages for InvocationID,
+// terminated by a completionMessage.
+type streamInvocationMessage struct {
+	Type         int           `json:"type"`
+	InvocationID string        `json:"invocationId"`
+	Target       string        `json:"target"`
+	Arguments    []interface{} `json:"arguments"`
+}
+
+// cancelInvocationMessage is message type 5, sent by a client to cancel a streamInvocationMessage
+// it previously sent.
+type cancelInvocationMessage struct {
+	Type         int    `json:"type"`
+	InvocationID string `json:"invocationId"`
+}
+
+// streamSubscription correlates one StreamItems call to its invocation ID. in is fed by
+// Receive() and is buffered (see streamSubscriptionBuffer); a dedicated forwarder goroutine
+// drains it onto the unbuffered, caller-facing out. A slow StreamItems consumer stalls only its
+// own forwarder until in is full; after that, Receive() blocks on it too.
+type streamSubscription struct {
+	in  chan interface{}
+	out chan interface{}
+}
+
+// streamSubscriptionBuffer is how many undelivered stream items Receive() will buffer for a
+// single invocation before it starts blocking, i.e. how far behind a slow StreamItems consumer
+// can fall before it back-pressures the connection.
+const streamSubscriptionBuffer = 32
+
+// StreamItems sends a streamInvocationMessage for target and returns a channel fed with every
+// streamItemMessage.Item the server sends for it, in order. The channel is closed once the
+// server answers with the matching completionMessage, or once Receive stops returning messages
+// (e.g. the connection is aborted), in which case no further items are delivered. Call cancel to
+// send a cancelInvocationMessage for the invocation; it does not close the channel itself, the
+// server's completionMessage still does.
+//
+// StreamItems does not call Receive() itself: whoever already owns the hubConnection's Receive()
+// loop keeps doing so, and every message it reads is also offered to StreamItems's bookkeeping
+// before being returned to that caller as usual.
+func (c *defaultHubConnection) StreamItems(target string, args ...interface{}) (<-chan interface{}, func() error, error) {
+	// The subscription is registered before the invocation is sent, so no item or completion the
+	// server sends back can reach dispatchStreamMessage before it exists.
+	invocationID := c.newInvocationID()
+	sub := &streamSubscription{
+		in:  make(chan interface{}, streamSubscriptionBuffer),
+		out: make(chan interface{}),
+	}
+	c.streamsMu.Lock()
+	c.streams[invocationID] = sub
+	c.streamsMu.Unlock()
+
+	if err := c.writeMessage(streamInvocationMessage{Type: 4, InvocationID: invocationID, Target: target, Arguments: args}); err != nil {
+		c.streamsMu.Lock()
+		delete(c.streams, invocationID)
+		c.streamsMu.Unlock()
+		return nil, nil, err
+	}
+
+	go forwardStream(sub)
+
+	cancel := func() error {
+		_, err := c.SendCancelInvocation(invocationID)
+		return err
+	}
+
+	return sub.out, cancel, nil
+}
+
+// forwardStream drains sub.in onto sub.out until sub.in is closed, then closes sub.out.
+func forwardStream(sub *streamSubscription) {
+	defer close(sub.out)
+	for item := range sub.in {
+		sub.out <- item
+	}
+}
+
+// dispatchStreamMessage offers message to any streamSubscription it belongs to. It is called by
+// Receive() for every message it reads, in addition to message being returned to Receive()'s own
+// caller as before — StreamItems adds a bystander, not a second reader.
+func (c *defaultHubConnection) dispatchStreamMessage(message interface{}) {
+	switch m := message.(type) {
+	case streamItemMessage:
+		c.streamsMu.Lock()
+		sub, ok := c.streams[m.InvocationID]
+		c.streamsMu.Unlock()
+		if ok {
+			sub.in <- m.Item
+		}
+	case completionMessage:
+		c.streamsMu.Lock()
+		sub, ok := c.streams[m.InvocationID]
+		delete(c.streams, m.InvocationID)
+		c.streamsMu.Unlock()
+		if ok {
+			close(sub.in)
+		}
+	}
+}
+
+// closeAllStreams closes every still-open stream subscription's forwarder, used once Receive
+// stops returning messages so no StreamItems caller is left blocked on its channel forever.
+func (c *defaultHubConnection) closeAllStreams() {
+	c.streamsMu.Lock()
+	defer c.streamsMu.Unlock()
+	for id, sub := range c.streams {
+		close(sub.in)
+		delete(c.streams, id)
+	}
+}
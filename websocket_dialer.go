@@ -0,0 +1,76 @@
+package signalr
+
+import (
+	"context"
+	"net/http"
+
+	"nhooyr.io/websocket"
+)
+
+// WebSocketMessageType distinguishes text and binary WebSocket frames without tying the
+// WebSocketDialer contract to any particular client implementation.
+type WebSocketMessageType int
+
+const (
+	WebSocketMessageText WebSocketMessageType = iota
+	WebSocketMessageBinary
+)
+
+// WebSocketConn is the minimal surface newWebSocketConnection needs from a dialed WebSocket,
+// satisfied by the default nhooyr.io/websocket backed implementation and by adapters such as
+// signalr/wsgorilla for github.com/gorilla/websocket.
+type WebSocketConn interface {
+	Read(ctx context.Context) (WebSocketMessageType, []byte, error)
+	Write(ctx context.Context, typ WebSocketMessageType, data []byte) error
+	Close(code int, reason string) error
+}
+
+// WebSocketDialer opens a WebSocket connection to url, carrying header. Implement this to plug
+// in an alternate WebSocket client (e.g. gorilla/websocket) via WithWebSocketDialer.
+type WebSocketDialer interface {
+	Dial(ctx context.Context, url string, header http.Header) (WebSocketConn, error)
+}
+
+// nhooyrWebSocketDialer is the default WebSocketDialer, backed by nhooyr.io/websocket.
+type nhooyrWebSocketDialer struct {
+	compression *CompressionOptions
+}
+
+func (d nhooyrWebSocketDialer) Dial(ctx context.Context, url string, header http.Header) (WebSocketConn, error) {
+	dialOpts := &websocket.DialOptions{HTTPHeader: header}
+	if d.compression != nil {
+		dialOpts.CompressionMode = d.compression.Mode.nhooyrMode()
+		dialOpts.CompressionThreshold = d.compression.threshold()
+	}
+
+	ws, _, err := websocket.Dial(ctx, url, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &nhooyrWebSocketConn{conn: ws}, nil
+}
+
+// nhooyrWebSocketConn adapts *websocket.Conn to WebSocketConn.
+type nhooyrWebSocketConn struct {
+	conn *websocket.Conn
+}
+
+func (c *nhooyrWebSocketConn) Read(ctx context.Context) (WebSocketMessageType, []byte, error) {
+	typ, data, err := c.conn.Read(ctx)
+	if typ == websocket.MessageBinary {
+		return WebSocketMessageBinary, data, err
+	}
+	return WebSocketMessageText, data, err
+}
+
+func (c *nhooyrWebSocketConn) Write(ctx context.Context, typ WebSocketMessageType, data []byte) error {
+	wsType := websocket.MessageText
+	if typ == WebSocketMessageBinary {
+		wsType = websocket.MessageBinary
+	}
+	return c.conn.Write(ctx, wsType, data)
+}
+
+func (c *nhooyrWebSocketConn) Close(code int, reason string) error {
+	return c.conn.Close(websocket.StatusCode(code), reason)
+}
@@ -0,0 +1,60 @@
+// Package wsgorilla provides a signalr.WebSocketDialer backed by github.com/gorilla/websocket,
+// for shops standardised on gorilla or needing its per-message deflate, subprotocol negotiation
+// or compatibility with existing proxy/middleware code.
+package wsgorilla
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ByungJun25/signalr"
+)
+
+// Dialer is a signalr.WebSocketDialer backed by a gorilla/websocket.Dialer.
+type Dialer struct {
+	Dialer websocket.Dialer
+}
+
+// NewDialer creates a Dialer using websocket.DefaultDialer's settings.
+func NewDialer() *Dialer {
+	return &Dialer{Dialer: *websocket.DefaultDialer}
+}
+
+func (d *Dialer) Dial(ctx context.Context, url string, header http.Header) (signalr.WebSocketConn, error) {
+	conn, _, err := d.Dialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+	return &wsConn{conn: conn}, nil
+}
+
+// wsConn adapts *websocket.Conn to signalr.WebSocketConn.
+type wsConn struct {
+	conn *websocket.Conn
+}
+
+func (c *wsConn) Read(ctx context.Context) (signalr.WebSocketMessageType, []byte, error) {
+	typ, data, err := c.conn.ReadMessage()
+	if err != nil {
+		return 0, nil, err
+	}
+	if typ == websocket.BinaryMessage {
+		return signalr.WebSocketMessageBinary, data, nil
+	}
+	return signalr.WebSocketMessageText, data, nil
+}
+
+func (c *wsConn) Write(ctx context.Context, typ signalr.WebSocketMessageType, data []byte) error {
+	wsType := websocket.TextMessage
+	if typ == signalr.WebSocketMessageBinary {
+		wsType = websocket.BinaryMessage
+	}
+	return c.conn.WriteMessage(wsType, data)
+}
+
+func (c *wsConn) Close(code int, reason string) error {
+	_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason))
+	return c.conn.Close()
+}
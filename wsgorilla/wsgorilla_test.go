@@ -0,0 +1,96 @@
+package wsgorilla
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ByungJun25/signalr"
+)
+
+// newEchoServer starts an httptest server that upgrades every request to a WebSocket and echoes
+// back whatever it reads.
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		for {
+			typ, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(typ, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDialer_RoundTripText(t *testing.T) {
+	server := newEchoServer(t)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dialer := NewDialer()
+	conn, err := dialer.Dial(context.Background(), url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer func() { _ = conn.Close(websocket.CloseNormalClosure, "") }()
+
+	if err := conn.Write(context.Background(), signalr.WebSocketMessageText, []byte("hello")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	typ, data, err := conn.Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if typ != signalr.WebSocketMessageText {
+		t.Fatalf("Read() type = %v, want WebSocketMessageText", typ)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("Read() data = %q, want %q", data, "hello")
+	}
+}
+
+func TestDialer_RoundTripBinary(t *testing.T) {
+	server := newEchoServer(t)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dialer := NewDialer()
+	conn, err := dialer.Dial(context.Background(), url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer func() { _ = conn.Close(websocket.CloseNormalClosure, "") }()
+
+	payload := []byte{0x01, 0x02, 0x03}
+	if err := conn.Write(context.Background(), signalr.WebSocketMessageBinary, payload); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	typ, data, err := conn.Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if typ != signalr.WebSocketMessageBinary {
+		t.Fatalf("Read() type = %v, want WebSocketMessageBinary", typ)
+	}
+	if string(data) != string(payload) {
+		t.Fatalf("Read() data = % x, want % x", data, payload)
+	}
+}